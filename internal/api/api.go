@@ -132,6 +132,8 @@ func (a *API) Initialize() error {
 
 	group.GET("/paths/list", a.onPathsList)
 	group.GET("/paths/get/*name", a.onPathsGet)
+	group.POST("/paths/ptz/move/*name", a.onPathsPTZMove)
+	group.POST("/paths/ptz/stop/*name", a.onPathsPTZStop)
 
 	if !interfaceIsEmpty(a.HLSServer) {
 		group.GET("/hlsmuxers/list", a.onHLSMuxersList)
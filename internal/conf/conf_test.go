@@ -48,40 +48,44 @@ func TestConfFromFile(t *testing.T) {
 		pa, ok := conf.Paths["cam1"]
 		require.Equal(t, true, ok)
 		require.Equal(t, &Path{
-			Name:                       "cam1",
-			Source:                     "publisher",
-			SourceOnDemandStartTimeout: 10 * Duration(time.Second),
-			SourceOnDemandCloseAfter:   10 * Duration(time.Second),
-			RecordPath:                 "./recordings/%path/%Y-%m-%d_%H-%M-%S-%f",
-			RecordFormat:               RecordFormatFMP4,
-			RecordPartDuration:         Duration(1 * time.Second),
-			RecordMaxPartSize:          50 * 1024 * 1024,
-			RecordSegmentDuration:      3600000000000,
-			RecordDeleteAfter:          86400000000000,
-			OverridePublisher:          true,
-			RPICameraWidth:             1920,
-			RPICameraHeight:            1080,
-			RPICameraContrast:          1,
-			RPICameraSaturation:        1,
-			RPICameraSharpness:         1,
-			RPICameraExposure:          "normal",
-			RPICameraAWB:               "auto",
-			RPICameraAWBGains:          []float64{0, 0},
-			RPICameraDenoise:           "off",
-			RPICameraMetering:          "centre",
-			RPICameraFPS:               30,
-			RPICameraAfMode:            "continuous",
-			RPICameraAfRange:           "normal",
-			RPICameraAfSpeed:           "normal",
-			RPICameraTextOverlay:       "%Y-%m-%d %H:%M:%S - MediaMTX",
-			RPICameraCodec:             "auto",
-			RPICameraIDRPeriod:         60,
-			RPICameraBitrate:           5000000,
-			RPICameraProfile:           "main",
-			RPICameraLevel:             "4.1",
-			RPICameraJPEGQuality:       60,
-			RunOnDemandStartTimeout:    5 * Duration(time.Second),
-			RunOnDemandCloseAfter:      10 * Duration(time.Second),
+			Name:                          "cam1",
+			Source:                        "publisher",
+			SourceOnDemandStartTimeout:    10 * Duration(time.Second),
+			SourceOnDemandCloseAfter:      10 * Duration(time.Second),
+			RecordPath:                    "./recordings/%path/%Y-%m-%d_%H-%M-%S-%f",
+			RecordFormat:                  RecordFormatFMP4,
+			RecordPartDuration:            Duration(1 * time.Second),
+			RecordMaxPartSize:             50 * 1024 * 1024,
+			RecordSegmentDuration:         3600000000000,
+			RecordDeleteAfter:             86400000000000,
+			RecordRetentionPolicy:         RecordRetentionPolicyTime,
+			OverridePublisher:             true,
+			ReadBufferOverflowPolicy:      ReadBufferOverflowPolicyDropOldest,
+			SourceResolverQuality:         "best",
+			SourceResolverRefreshInterval: 10 * 60 * Duration(time.Second),
+			RPICameraWidth:                1920,
+			RPICameraHeight:               1080,
+			RPICameraContrast:             1,
+			RPICameraSaturation:           1,
+			RPICameraSharpness:            1,
+			RPICameraExposure:             "normal",
+			RPICameraAWB:                  "auto",
+			RPICameraAWBGains:             []float64{0, 0},
+			RPICameraDenoise:              "off",
+			RPICameraMetering:             "centre",
+			RPICameraFPS:                  30,
+			RPICameraAfMode:               "continuous",
+			RPICameraAfRange:              "normal",
+			RPICameraAfSpeed:              "normal",
+			RPICameraTextOverlay:          "%Y-%m-%d %H:%M:%S - MediaMTX",
+			RPICameraCodec:                "auto",
+			RPICameraIDRPeriod:            60,
+			RPICameraBitrate:              5000000,
+			RPICameraProfile:              "main",
+			RPICameraLevel:                "4.1",
+			RPICameraJPEGQuality:          60,
+			RunOnDemandStartTimeout:       5 * Duration(time.Second),
+			RunOnDemandCloseAfter:         10 * Duration(time.Second),
 		}, pa)
 	}()
 
@@ -436,6 +440,53 @@ func TestConfErrors(t *testing.T) {
 				"    recordDeleteAfter: 20m\n",
 			`'recordDeleteAfter' cannot be lower than 'recordSegmentDuration'`,
 		},
+		{
+			"invalid record max disk usage percent",
+			"paths:\n" +
+				"  my_path:\n" +
+				"    recordMaxDiskUsagePercent: 150\n",
+			`'recordMaxDiskUsagePercent' must be in range (0, 100]`,
+		},
+		{
+			"missing record retention criterion",
+			"paths:\n" +
+				"  my_path:\n" +
+				"    record: true\n" +
+				"    recordDeleteAfter: 0s\n",
+			`when 'record' is true, at least one retention criterion must be set` +
+				` ('recordDeleteAfter', 'recordMaxTotalSize' or 'recordMaxDiskUsagePercent')`,
+		},
+		{
+			"invalid read buffer duration",
+			"paths:\n" +
+				"  my_path:\n" +
+				"    readBufferDuration: -1s\n",
+			`'readBufferDuration' must be greater than or equal to 0`,
+		},
+		{
+			"invalid read buffer max packets",
+			"paths:\n" +
+				"  my_path:\n" +
+				"    readBufferMaxPackets: -1\n",
+			`'readBufferMaxPackets' must be greater than or equal to 0`,
+		},
+		{
+			"invalid onvif device url",
+			"paths:\n" +
+				"  my_path:\n" +
+				"    source: onvif\n",
+			`'onvifDeviceUrl' must be filled when source is 'onvif'`,
+		},
+		{
+			"invalid onvif profile token",
+			"paths:\n" +
+				"  my_path:\n" +
+				"    source: onvif\n" +
+				"    onvifDeviceUrl: http://192.168.1.1/onvif/device_service\n" +
+				"    onvifPTZEnable: yes\n",
+			`'onvifProfileToken' must be filled when 'onvifPTZEnable' is true,` +
+				` since the PTZ-capable profile must be selected explicitly`,
+		},
 	} {
 		t.Run(ca.name, func(t *testing.T) {
 			tmpf, err := createTempFile([]byte(ca.conf))
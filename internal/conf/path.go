@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net"
 	gourl "net/url"
+	"os/exec"
 	"reflect"
 	"regexp"
 	"sort"
@@ -122,6 +123,11 @@ type Path struct {
 	Fallback                   string   `json:"fallback"`
 	UseAbsoluteTimestamp       bool     `json:"useAbsoluteTimestamp"`
 
+	// Read buffer
+	ReadBufferDuration       Duration                 `json:"readBufferDuration"`
+	ReadBufferMaxPackets     int                      `json:"readBufferMaxPackets"`
+	ReadBufferOverflowPolicy ReadBufferOverflowPolicy `json:"readBufferOverflowPolicy"`
+
 	// Record
 	Record                bool         `json:"record"`
 	Playback              *bool        `json:"playback,omitempty"` // deprecated
@@ -132,6 +138,11 @@ type Path struct {
 	RecordSegmentDuration Duration     `json:"recordSegmentDuration"`
 	RecordDeleteAfter     Duration     `json:"recordDeleteAfter"`
 
+	// Record retention
+	RecordRetentionPolicy     RecordRetentionPolicy `json:"recordRetentionPolicy"`
+	RecordMaxTotalSize        StringSize            `json:"recordMaxTotalSize"`
+	RecordMaxDiskUsagePercent float64               `json:"recordMaxDiskUsagePercent"`
+
 	// Authentication (deprecated)
 	PublishUser *Credential `json:"publishUser,omitempty"` // deprecated
 	PublishPass *Credential `json:"publishPass,omitempty"` // deprecated
@@ -156,6 +167,22 @@ type Path struct {
 	// Redirect source
 	SourceRedirect string `json:"sourceRedirect"`
 
+	// Source resolver
+	SourceResolverCookies         string   `json:"sourceResolverCookies"`
+	SourceResolverQuality         string   `json:"sourceResolverQuality"`
+	SourceResolverRefreshInterval Duration `json:"sourceResolverRefreshInterval"`
+
+	// ONVIF source
+	ONVIFDeviceURL string `json:"onvifDeviceUrl"`
+	// ONVIFUsername and ONVIFPassword are plain strings, not Credential:
+	// they are sent outbound as HTTP Basic Auth to the camera, rather than
+	// checked against an inbound guess, so they don't support hashing and
+	// allow any character a real device password may contain.
+	ONVIFUsername     string `json:"onvifUsername"`
+	ONVIFPassword     string `json:"onvifPassword"`
+	ONVIFProfileToken string `json:"onvifProfileToken"`
+	ONVIFPTZEnable    bool   `json:"onvifPTZEnable"`
+
 	// Raspberry Pi Camera source
 	RPICameraCamID                uint      `json:"rpiCameraCamID"`
 	RPICameraSecondary            bool      `json:"rpiCameraSecondary"`
@@ -224,6 +251,9 @@ func (pconf *Path) setDefaults() {
 	pconf.SourceOnDemandStartTimeout = 10 * Duration(time.Second)
 	pconf.SourceOnDemandCloseAfter = 10 * Duration(time.Second)
 
+	// Read buffer
+	pconf.ReadBufferOverflowPolicy = ReadBufferOverflowPolicyDropOldest
+
 	// Record
 	pconf.RecordPath = "./recordings/%path/%Y-%m-%d_%H-%M-%S-%f"
 	pconf.RecordFormat = RecordFormatFMP4
@@ -231,10 +261,15 @@ func (pconf *Path) setDefaults() {
 	pconf.RecordMaxPartSize = 50 * 1024 * 1024
 	pconf.RecordSegmentDuration = 3600 * Duration(time.Second)
 	pconf.RecordDeleteAfter = 24 * 3600 * Duration(time.Second)
+	pconf.RecordRetentionPolicy = RecordRetentionPolicyTime
 
 	// Publisher source
 	pconf.OverridePublisher = true
 
+	// Source resolver
+	pconf.SourceResolverQuality = "best"
+	pconf.SourceResolverRefreshInterval = 10 * 60 * Duration(time.Second)
+
 	// Raspberry Pi Camera source
 	pconf.RPICameraWidth = 1920
 	pconf.RPICameraHeight = 1080
@@ -339,6 +374,14 @@ func (pconf *Path) validate(
 		return fmt.Errorf("'sourceRedirect' is useless when source is not 'redirect'")
 	}
 
+	if pconf.ReadBufferDuration < 0 {
+		return fmt.Errorf("'readBufferDuration' must be greater than or equal to 0")
+	}
+
+	if pconf.ReadBufferMaxPackets < 0 {
+		return fmt.Errorf("'readBufferMaxPackets' must be greater than or equal to 0")
+	}
+
 	// source-dependent settings
 
 	switch {
@@ -428,6 +471,22 @@ func (pconf *Path) validate(
 			return fmt.Errorf("'%s' is not a valid URL", pconf.Source)
 		}
 
+	case strings.HasPrefix(pconf.Source, "bilibili://") ||
+		strings.HasPrefix(pconf.Source, "youtube://") ||
+		strings.HasPrefix(pconf.Source, "twitch://"):
+		if pconf.Source[strings.Index(pconf.Source, "://")+len("://"):] == "" {
+			return fmt.Errorf("'%s' must contain a channel or video identifier", pconf.Source)
+		}
+
+		if pconf.SourceResolverRefreshInterval <= 0 {
+			return fmt.Errorf("'sourceResolverRefreshInterval' must be greater than zero")
+		}
+
+		if _, err := exec.LookPath("yt-dlp"); err != nil {
+			l.Log(logger.Warn, "path '%s' resolves its source through yt-dlp, "+
+				"but the 'yt-dlp' executable was not found in PATH", name)
+		}
+
 	case pconf.Source == "redirect":
 		if pconf.SourceRedirect == "" {
 			return fmt.Errorf("source redirect must be filled")
@@ -438,6 +497,21 @@ func (pconf *Path) validate(
 			return err
 		}
 
+	case pconf.Source == "onvif":
+		if pconf.ONVIFDeviceURL == "" {
+			return fmt.Errorf("'onvifDeviceUrl' must be filled when source is 'onvif'")
+		}
+
+		_, err := gourl.Parse(pconf.ONVIFDeviceURL)
+		if err != nil {
+			return fmt.Errorf("'%s' is not a valid URL", pconf.ONVIFDeviceURL)
+		}
+
+		if pconf.ONVIFPTZEnable && pconf.ONVIFProfileToken == "" {
+			return fmt.Errorf("'onvifProfileToken' must be filled when 'onvifPTZEnable' is true," +
+				" since the PTZ-capable profile must be selected explicitly")
+		}
+
 	case pconf.Source == "rpiCamera":
 
 		if pconf.RPICameraWidth == 0 {
@@ -598,6 +672,31 @@ func (pconf *Path) validate(
 		return fmt.Errorf("'recordDeleteAfter' cannot be lower than 'recordSegmentDuration'")
 	}
 
+	if pconf.RecordMaxDiskUsagePercent != 0 &&
+		(pconf.RecordMaxDiskUsagePercent < 0 || pconf.RecordMaxDiskUsagePercent > 100) {
+		return fmt.Errorf("'recordMaxDiskUsagePercent' must be in range (0, 100]")
+	}
+
+	if pconf.Record &&
+		pconf.RecordDeleteAfter == 0 &&
+		pconf.RecordMaxTotalSize == 0 &&
+		pconf.RecordMaxDiskUsagePercent == 0 {
+		return fmt.Errorf("when 'record' is true, at least one retention criterion must be set" +
+			" ('recordDeleteAfter', 'recordMaxTotalSize' or 'recordMaxDiskUsagePercent')")
+	}
+
+	switch pconf.RecordRetentionPolicy {
+	case RecordRetentionPolicySize, RecordRetentionPolicyFIFO:
+		if pconf.Record && pconf.RecordMaxTotalSize == 0 {
+			return fmt.Errorf("'recordRetentionPolicy' is '%s' but 'recordMaxTotalSize' is not set", pconf.RecordRetentionPolicy)
+		}
+
+	case RecordRetentionPolicyDisk:
+		if pconf.Record && pconf.RecordMaxDiskUsagePercent == 0 {
+			return fmt.Errorf("'recordRetentionPolicy' is 'disk' but 'recordMaxDiskUsagePercent' is not set")
+		}
+	}
+
 	// Authentication (deprecated)
 
 	if deprecatedCredentialsMode {
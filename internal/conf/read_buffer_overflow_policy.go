@@ -0,0 +1,39 @@
+package conf
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediamtx/internal/conf/jsonwrapper"
+)
+
+// ReadBufferOverflowPolicy is the readBufferOverflowPolicy parameter.
+type ReadBufferOverflowPolicy string
+
+// supported values.
+const (
+	ReadBufferOverflowPolicyDropOldest       ReadBufferOverflowPolicy = "drop-oldest"
+	ReadBufferOverflowPolicyDropNewest       ReadBufferOverflowPolicy = "drop-newest"
+	ReadBufferOverflowPolicyDisconnectReader ReadBufferOverflowPolicy = "disconnect-reader"
+)
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *ReadBufferOverflowPolicy) UnmarshalJSON(b []byte) error {
+	type alias ReadBufferOverflowPolicy
+	if err := jsonwrapper.Unmarshal(b, (*alias)(d)); err != nil {
+		return err
+	}
+
+	switch *d {
+	case ReadBufferOverflowPolicyDropOldest, ReadBufferOverflowPolicyDropNewest, ReadBufferOverflowPolicyDisconnectReader:
+
+	default:
+		return fmt.Errorf("invalid read buffer overflow policy '%s'", *d)
+	}
+
+	return nil
+}
+
+// UnmarshalEnv implements env.Unmarshaler.
+func (d *ReadBufferOverflowPolicy) UnmarshalEnv(_ string, v string) error {
+	return d.UnmarshalJSON([]byte(`"` + v + `"`))
+}
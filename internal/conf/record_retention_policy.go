@@ -0,0 +1,40 @@
+package conf
+
+import (
+	"fmt"
+
+	"github.com/bluenviron/mediamtx/internal/conf/jsonwrapper"
+)
+
+// RecordRetentionPolicy is the recordRetentionPolicy parameter.
+type RecordRetentionPolicy string
+
+// supported values.
+const (
+	RecordRetentionPolicyTime RecordRetentionPolicy = "time"
+	RecordRetentionPolicySize RecordRetentionPolicy = "size"
+	RecordRetentionPolicyFIFO RecordRetentionPolicy = "fifo"
+	RecordRetentionPolicyDisk RecordRetentionPolicy = "disk"
+)
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *RecordRetentionPolicy) UnmarshalJSON(b []byte) error {
+	type alias RecordRetentionPolicy
+	if err := jsonwrapper.Unmarshal(b, (*alias)(d)); err != nil {
+		return err
+	}
+
+	switch *d {
+	case RecordRetentionPolicyTime, RecordRetentionPolicySize, RecordRetentionPolicyFIFO, RecordRetentionPolicyDisk:
+
+	default:
+		return fmt.Errorf("invalid record retention policy '%s'", *d)
+	}
+
+	return nil
+}
+
+// UnmarshalEnv implements env.Unmarshaler.
+func (d *RecordRetentionPolicy) UnmarshalEnv(_ string, v string) error {
+	return d.UnmarshalJSON([]byte(`"` + v + `"`))
+}
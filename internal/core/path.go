@@ -63,6 +63,17 @@ type pathAPIPathsGetReq struct {
 	res  chan pathAPIPathsGetRes
 }
 
+type pathPTZMoveReq struct {
+	pan  float64
+	tilt float64
+	zoom float64
+	res  chan error
+}
+
+type pathPTZStopReq struct {
+	res chan error
+}
+
 type path struct {
 	parentCtx         context.Context
 	logLevel          conf.LogLevel
@@ -111,6 +122,8 @@ type path struct {
 	chAddReader               chan defs.PathAddReaderReq
 	chRemoveReader            chan defs.PathRemoveReaderReq
 	chAPIPathsGet             chan pathAPIPathsGetReq
+	chPTZMove                 chan pathPTZMoveReq
+	chPTZStop                 chan pathPTZStopReq
 
 	// out
 	done chan struct{}
@@ -137,6 +150,8 @@ func (pa *path) initialize() {
 	pa.chAddReader = make(chan defs.PathAddReaderReq)
 	pa.chRemoveReader = make(chan defs.PathRemoveReaderReq)
 	pa.chAPIPathsGet = make(chan pathAPIPathsGetReq)
+	pa.chPTZMove = make(chan pathPTZMoveReq)
+	pa.chPTZStop = make(chan pathPTZStopReq)
 	pa.done = make(chan struct{})
 
 	pa.Log(logger.Debug, "created")
@@ -315,6 +330,12 @@ func (pa *path) runInner() error {
 		case req := <-pa.chAPIPathsGet:
 			pa.doAPIPathsGet(req)
 
+		case req := <-pa.chPTZMove:
+			pa.doPTZMove(req)
+
+		case req := <-pa.chPTZStop:
+			pa.doPTZStop(req)
+
 		case <-pa.ctx.Done():
 			return fmt.Errorf("terminated")
 		}
@@ -613,10 +634,60 @@ func (pa *path) doAPIPathsGet(req pathAPIPathsGetReq) {
 				}
 				return ret
 			}(),
+			ReadBufferDepth: func() int {
+				if pa.stream == nil {
+					return 0
+				}
+				return pa.stream.ReadBufferDepth()
+			}(),
+			ReadBufferDropped: func() uint64 {
+				if pa.stream == nil {
+					return 0
+				}
+				return pa.stream.ReadBufferDroppedPackets()
+			}(),
+			ReadBufferOldestPacket: func() float64 {
+				if pa.stream == nil {
+					return 0
+				}
+				return pa.stream.ReadBufferOldestPacketAge().Seconds()
+			}(),
 		},
 	}
 }
 
+func (pa *path) doPTZMove(req pathPTZMoveReq) {
+	source, ok := pa.source.(*staticSourceHandler)
+	if !ok {
+		req.res <- fmt.Errorf("path '%s' has no static source", pa.name)
+		return
+	}
+
+	ptz, ok := source.instance.(defs.StaticSourcePTZ)
+	if !ok {
+		req.res <- fmt.Errorf("source of path '%s' does not support PTZ", pa.name)
+		return
+	}
+
+	req.res <- ptz.PTZMove(pa.ctx, req.pan, req.tilt, req.zoom)
+}
+
+func (pa *path) doPTZStop(req pathPTZStopReq) {
+	source, ok := pa.source.(*staticSourceHandler)
+	if !ok {
+		req.res <- fmt.Errorf("path '%s' has no static source", pa.name)
+		return
+	}
+
+	ptz, ok := source.instance.(defs.StaticSourcePTZ)
+	if !ok {
+		req.res <- fmt.Errorf("source of path '%s' does not support PTZ", pa.name)
+		return
+	}
+
+	req.res <- ptz.PTZStop(pa.ctx)
+}
+
 func (pa *path) SafeConf() *conf.Path {
 	pa.confMutex.RLock()
 	defer pa.confMutex.RUnlock()
@@ -710,13 +781,17 @@ func (pa *path) onDemandPublisherStop(reason string) {
 }
 
 func (pa *path) setReady(desc *description.Session, allocateEncoder bool) error {
-	var err error
-	pa.stream, err = stream.New(
-		pa.udpMaxPayloadSize,
-		desc,
-		allocateEncoder,
-		logger.NewLimitedLogger(pa.source),
-	)
+	pa.stream = &stream.Stream{
+		WriteQueueSize:           pa.writeQueueSize,
+		RTPMaxPayloadSize:        pa.udpMaxPayloadSize,
+		Desc:                     desc,
+		GenerateRTPPackets:       allocateEncoder,
+		ReadBufferDuration:       time.Duration(pa.conf.ReadBufferDuration),
+		ReadBufferMaxPackets:     pa.conf.ReadBufferMaxPackets,
+		ReadBufferOverflowPolicy: string(pa.conf.ReadBufferOverflowPolicy),
+		Parent:                   logger.NewLimitedLogger(pa.source),
+	}
+	err := pa.stream.Initialize()
 	if err != nil {
 		return err
 	}
@@ -997,3 +1072,27 @@ func (pa *path) APIPathsGet(req pathAPIPathsGetReq) (*defs.APIPath, error) {
 		return nil, fmt.Errorf("terminated")
 	}
 }
+
+// PTZMove is called by api.
+func (pa *path) PTZMove(pan float64, tilt float64, zoom float64) error {
+	req := pathPTZMoveReq{pan: pan, tilt: tilt, zoom: zoom, res: make(chan error)}
+	select {
+	case pa.chPTZMove <- req:
+		return <-req.res
+
+	case <-pa.ctx.Done():
+		return fmt.Errorf("terminated")
+	}
+}
+
+// PTZStop is called by api.
+func (pa *path) PTZStop() error {
+	req := pathPTZStopReq{res: make(chan error)}
+	select {
+	case pa.chPTZStop <- req:
+		return <-req.res
+
+	case <-pa.ctx.Done():
+		return fmt.Errorf("terminated")
+	}
+}
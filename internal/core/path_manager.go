@@ -67,6 +67,29 @@ type pathData struct {
 	confName string
 }
 
+type pathManagerPTZMoveRes struct {
+	path *path
+	err  error
+}
+
+type pathManagerPTZMoveReq struct {
+	name string
+	pan  float64
+	tilt float64
+	zoom float64
+	res  chan pathManagerPTZMoveRes
+}
+
+type pathManagerPTZStopRes struct {
+	path *path
+	err  error
+}
+
+type pathManagerPTZStopReq struct {
+	name string
+	res  chan pathManagerPTZStopRes
+}
+
 type pathManagerParent interface {
 	logger.Writer
 }
@@ -104,6 +127,8 @@ type pathManager struct {
 	chAddPublisher    chan defs.PathAddPublisherReq
 	chAPIPathsList    chan pathAPIPathsListReq
 	chAPIPathsGet     chan pathAPIPathsGetReq
+	chPTZMove         chan pathManagerPTZMoveReq
+	chPTZStop         chan pathManagerPTZStopReq
 	chKeepaliveAdd    chan pathKeepaliveAddReq
 	chKeepaliveRemove chan pathKeepaliveRemoveReq
 	chKeepalivesList  chan pathKeepalivesListReq
@@ -128,6 +153,8 @@ func (pm *pathManager) initialize() {
 	pm.chAddPublisher = make(chan defs.PathAddPublisherReq)
 	pm.chAPIPathsList = make(chan pathAPIPathsListReq)
 	pm.chAPIPathsGet = make(chan pathAPIPathsGetReq)
+	pm.chPTZMove = make(chan pathManagerPTZMoveReq)
+	pm.chPTZStop = make(chan pathManagerPTZStopReq)
 	pm.chKeepaliveAdd = make(chan pathKeepaliveAddReq)
 	pm.chKeepaliveRemove = make(chan pathKeepaliveRemoveReq)
 	pm.chKeepalivesList = make(chan pathKeepalivesListReq)
@@ -205,6 +232,12 @@ outer:
 		case req := <-pm.chAPIPathsGet:
 			pm.doAPIPathsGet(req)
 
+		case req := <-pm.chPTZMove:
+			pm.doPTZMove(req)
+
+		case req := <-pm.chPTZStop:
+			pm.doPTZStop(req)
+
 		case req := <-pm.chKeepaliveAdd:
 			pm.doKeepaliveAdd(req)
 
@@ -452,6 +485,26 @@ func (pm *pathManager) doAPIPathsGet(req pathAPIPathsGetReq) {
 	req.res <- pathAPIPathsGetRes{path: pd.path}
 }
 
+func (pm *pathManager) doPTZMove(req pathManagerPTZMoveReq) {
+	pd, ok := pm.paths[req.name]
+	if !ok {
+		req.res <- pathManagerPTZMoveRes{err: conf.ErrPathNotFound}
+		return
+	}
+
+	req.res <- pathManagerPTZMoveRes{path: pd.path}
+}
+
+func (pm *pathManager) doPTZStop(req pathManagerPTZStopReq) {
+	pd, ok := pm.paths[req.name]
+	if !ok {
+		req.res <- pathManagerPTZStopRes{err: conf.ErrPathNotFound}
+		return
+	}
+
+	req.res <- pathManagerPTZStopRes{path: pd.path}
+}
+
 func (pm *pathManager) createPath(
 	pathConf *conf.Path,
 	name string,
@@ -669,6 +722,51 @@ func (pm *pathManager) APIPathsGet(name string) (*defs.APIPath, error) {
 	}
 }
 
+// PTZMove is called by api.
+func (pm *pathManager) PTZMove(name string, pan float64, tilt float64, zoom float64) error {
+	req := pathManagerPTZMoveReq{
+		name: name,
+		pan:  pan,
+		tilt: tilt,
+		zoom: zoom,
+		res:  make(chan pathManagerPTZMoveRes),
+	}
+
+	select {
+	case pm.chPTZMove <- req:
+		res := <-req.res
+		if res.err != nil {
+			return res.err
+		}
+
+		return res.path.PTZMove(pan, tilt, zoom)
+
+	case <-pm.ctx.Done():
+		return fmt.Errorf("terminated")
+	}
+}
+
+// PTZStop is called by api.
+func (pm *pathManager) PTZStop(name string) error {
+	req := pathManagerPTZStopReq{
+		name: name,
+		res:  make(chan pathManagerPTZStopRes),
+	}
+
+	select {
+	case pm.chPTZStop <- req:
+		res := <-req.res
+		if res.err != nil {
+			return res.err
+		}
+
+		return res.path.PTZStop()
+
+	case <-pm.ctx.Done():
+		return fmt.Errorf("terminated")
+	}
+}
+
 type pathKeepaliveAddReq struct {
 	accessRequest defs.PathAccessRequest
 	res           chan pathKeepaliveAddRes
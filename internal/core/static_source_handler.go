@@ -11,6 +11,7 @@ import (
 	"github.com/bluenviron/mediamtx/internal/defs"
 	"github.com/bluenviron/mediamtx/internal/logger"
 	hlssource "github.com/bluenviron/mediamtx/internal/staticsources/hls"
+	onvifsource "github.com/bluenviron/mediamtx/internal/staticsources/onvif"
 	rpicamerasource "github.com/bluenviron/mediamtx/internal/staticsources/rpicamera"
 	rtmpsource "github.com/bluenviron/mediamtx/internal/staticsources/rtmp"
 	rtspsource "github.com/bluenviron/mediamtx/internal/staticsources/rtsp"
@@ -115,6 +116,14 @@ func (s *staticSourceHandler) initialize() {
 			Parent:      s,
 		}
 
+	case s.conf.Source == "onvif":
+		s.instance = &onvifsource.Source{
+			ReadTimeout:    s.readTimeout,
+			WriteTimeout:   s.writeTimeout,
+			WriteQueueSize: s.writeQueueSize,
+			Parent:         s,
+		}
+
 	case s.conf.Source == "rpiCamera":
 		s.instance = &rpicamerasource.Source{
 			LogLevel: s.logLevel,
@@ -85,15 +85,18 @@ type APIPathSourceOrReader struct {
 
 // APIPath is a path.
 type APIPath struct {
-	Name          string                  `json:"name"`
-	ConfName      string                  `json:"confName"`
-	Source        *APIPathSourceOrReader  `json:"source"`
-	Ready         bool                    `json:"ready"`
-	ReadyTime     *time.Time              `json:"readyTime"`
-	Tracks        []string                `json:"tracks"`
-	BytesReceived uint64                  `json:"bytesReceived"`
-	BytesSent     uint64                  `json:"bytesSent"`
-	Readers       []APIPathSourceOrReader `json:"readers"`
+	Name                   string                  `json:"name"`
+	ConfName               string                  `json:"confName"`
+	Source                 *APIPathSourceOrReader  `json:"source"`
+	Ready                  bool                    `json:"ready"`
+	ReadyTime              *time.Time              `json:"readyTime"`
+	Tracks                 []string                `json:"tracks"`
+	BytesReceived          uint64                  `json:"bytesReceived"`
+	BytesSent              uint64                  `json:"bytesSent"`
+	Readers                []APIPathSourceOrReader `json:"readers"`
+	ReadBufferDepth        int                     `json:"readBufferDepth"`
+	ReadBufferDropped      uint64                  `json:"readBufferDropped"`
+	ReadBufferOldestPacket float64                 `json:"readBufferOldestPacketAge"`
 }
 
 // APIPathList is a list of paths.
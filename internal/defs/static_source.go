@@ -13,3 +13,9 @@ type StaticSourceRunParams struct {
 	Conf           *conf.Path
 	ReloadConf     chan *conf.Path
 }
+
+// StaticSourcePTZ is implemented by static sources that support PTZ (pan, tilt, zoom) control.
+type StaticSourcePTZ interface {
+	PTZMove(ctx context.Context, pan float64, tilt float64, zoom float64) error
+	PTZStop(ctx context.Context) error
+}
@@ -0,0 +1,25 @@
+//go:build !windows
+
+package diskusage
+
+import (
+	"syscall"
+)
+
+// UsedPercent returns the percentage of used space in the filesystem that contains fpath.
+func UsedPercent(fpath string) (float64, error) {
+	var stat syscall.Statfs_t
+	err := syscall.Statfs(fpath, &stat)
+	if err != nil {
+		return 0, err
+	}
+
+	total := stat.Blocks * uint64(stat.Bsize)
+	free := stat.Bfree * uint64(stat.Bsize)
+
+	if total == 0 {
+		return 0, nil
+	}
+
+	return (float64(total-free) / float64(total)) * 100, nil
+}
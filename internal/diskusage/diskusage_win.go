@@ -0,0 +1,28 @@
+//go:build windows
+
+package diskusage
+
+import (
+	"golang.org/x/sys/windows"
+)
+
+// UsedPercent returns the percentage of used space in the filesystem that contains fpath.
+func UsedPercent(fpath string) (float64, error) {
+	var freeBytesAvailable, totalBytes, totalFreeBytes uint64
+
+	pathPtr, err := windows.UTF16PtrFromString(fpath)
+	if err != nil {
+		return 0, err
+	}
+
+	err = windows.GetDiskFreeSpaceEx(pathPtr, &freeBytesAvailable, &totalBytes, &totalFreeBytes)
+	if err != nil {
+		return 0, err
+	}
+
+	if totalBytes == 0 {
+		return 0, nil
+	}
+
+	return (float64(totalBytes-totalFreeBytes) / float64(totalBytes)) * 100, nil
+}
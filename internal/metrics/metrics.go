@@ -159,6 +159,9 @@ func (m *Metrics) onMetrics(ctx *gin.Context) {
 			out += metric("paths", tags, 1)
 			out += metric("paths_bytes_received", tags, int64(i.BytesReceived))
 			out += metric("paths_bytes_sent", tags, int64(i.BytesSent))
+			out += metric("paths_read_buffer_depth", tags, int64(i.ReadBufferDepth))
+			out += metric("paths_read_buffer_dropped_packets", tags, int64(i.ReadBufferDropped))
+			out += metricFloat("paths_read_buffer_oldest_packet_age_seconds", tags, i.ReadBufferOldestPacket)
 		}
 	} else {
 		out += metric("paths", "", 0)
@@ -0,0 +1,182 @@
+// Package onvif contains a minimal ONVIF SOAP client, used to discover the
+// RTSP stream URI of a camera and to send PTZ commands to it.
+package onvif
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const requestTimeout = 10 * time.Second
+
+// Profile is a media profile exposed by an ONVIF device.
+type Profile struct {
+	Token  string
+	Name   string
+	HasPTZ bool
+}
+
+// Client is a minimal ONVIF SOAP client.
+type Client struct {
+	DeviceURL string
+	Username  string
+	Password  string
+
+	httpClient *http.Client
+}
+
+// NewClient allocates a Client.
+func NewClient(deviceURL string, username string, password string) *Client {
+	return &Client{
+		DeviceURL: deviceURL,
+		Username:  username,
+		Password:  password,
+		httpClient: &http.Client{
+			Timeout: requestTimeout,
+		},
+	}
+}
+
+func (c *Client) do(ctx context.Context, action string, body string) ([]byte, error) {
+	envelope := `<?xml version="1.0" encoding="UTF-8"?>` +
+		`<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope">` +
+		`<soap:Body>` + body + `</soap:Body>` +
+		`</soap:Envelope>`
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.DeviceURL, bytes.NewReader([]byte(envelope)))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/soap+xml; charset=utf-8")
+	if c.Username != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", action, err)
+	}
+	defer res.Body.Close()
+
+	buf, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", action, err)
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf("%s: bad status code: %d", action, res.StatusCode)
+	}
+
+	return buf, nil
+}
+
+type getProfilesEnvelope struct {
+	Body struct {
+		GetProfilesResponse struct {
+			Profiles []struct {
+				Token string    `xml:"token,attr"`
+				Name  string    `xml:"Name"`
+				PTZ   *struct{} `xml:"PTZConfiguration"`
+			} `xml:"Profiles"`
+		} `xml:"GetProfilesResponse"`
+	} `xml:"Body"`
+}
+
+// GetProfiles returns the media profiles exposed by the device.
+func (c *Client) GetProfiles(ctx context.Context) ([]Profile, error) {
+	buf, err := c.do(ctx, "GetProfiles",
+		`<trt:GetProfiles xmlns:trt="http://www.onvif.org/ver10/media/wsdl"/>`)
+	if err != nil {
+		return nil, err
+	}
+
+	var env getProfilesEnvelope
+	err = xml.Unmarshal(buf, &env)
+	if err != nil {
+		return nil, fmt.Errorf("GetProfiles: invalid response: %w", err)
+	}
+
+	profiles := make([]Profile, len(env.Body.GetProfilesResponse.Profiles))
+	for i, p := range env.Body.GetProfilesResponse.Profiles {
+		profiles[i] = Profile{
+			Token:  p.Token,
+			Name:   p.Name,
+			HasPTZ: p.PTZ != nil,
+		}
+	}
+
+	return profiles, nil
+}
+
+type getStreamURIEnvelope struct {
+	Body struct {
+		GetStreamUriResponse struct {
+			MediaUri struct {
+				Uri string `xml:"Uri"`
+			} `xml:"MediaUri"`
+		} `xml:"GetStreamUriResponse"`
+	} `xml:"Body"`
+}
+
+// GetStreamURI returns the RTSP URI of the given profile.
+func (c *Client) GetStreamURI(ctx context.Context, profileToken string) (string, error) {
+	buf, err := c.do(ctx, "GetStreamUri", fmt.Sprintf(
+		`<trt:GetStreamUri xmlns:trt="http://www.onvif.org/ver10/media/wsdl">`+
+			`<trt:StreamSetup>`+
+			`<tt:Stream xmlns:tt="http://www.onvif.org/ver10/schema">RTP-Unicast</tt:Stream>`+
+			`<tt:Transport xmlns:tt="http://www.onvif.org/ver10/schema">`+
+			`<tt:Protocol>RTSP</tt:Protocol>`+
+			`</tt:Transport>`+
+			`</trt:StreamSetup>`+
+			`<trt:ProfileToken>%s</trt:ProfileToken>`+
+			`</trt:GetStreamUri>`,
+		profileToken))
+	if err != nil {
+		return "", err
+	}
+
+	var env getStreamURIEnvelope
+	err = xml.Unmarshal(buf, &env)
+	if err != nil {
+		return "", fmt.Errorf("GetStreamUri: invalid response: %w", err)
+	}
+
+	uri := env.Body.GetStreamUriResponse.MediaUri.Uri
+	if uri == "" {
+		return "", fmt.Errorf("GetStreamUri: device returned an empty URI")
+	}
+
+	return uri, nil
+}
+
+// PTZContinuousMove starts a continuous pan/tilt/zoom movement.
+func (c *Client) PTZContinuousMove(ctx context.Context, profileToken string, pan float64, tilt float64, zoom float64) error {
+	_, err := c.do(ctx, "ContinuousMove", fmt.Sprintf(
+		`<tptz:ContinuousMove xmlns:tptz="http://www.onvif.org/ver20/ptz/wsdl">`+
+			`<tptz:ProfileToken>%s</tptz:ProfileToken>`+
+			`<tptz:Velocity>`+
+			`<tt:PanTilt xmlns:tt="http://www.onvif.org/ver10/schema" x="%f" y="%f"/>`+
+			`<tt:Zoom xmlns:tt="http://www.onvif.org/ver10/schema" x="%f"/>`+
+			`</tptz:Velocity>`+
+			`</tptz:ContinuousMove>`,
+		profileToken, pan, tilt, zoom))
+	return err
+}
+
+// PTZStop stops any ongoing PTZ movement.
+func (c *Client) PTZStop(ctx context.Context, profileToken string) error {
+	_, err := c.do(ctx, "Stop", fmt.Sprintf(
+		`<tptz:Stop xmlns:tptz="http://www.onvif.org/ver20/ptz/wsdl">`+
+			`<tptz:ProfileToken>%s</tptz:ProfileToken>`+
+			`<tptz:PanTilt>true</tptz:PanTilt>`+
+			`<tptz:Zoom>true</tptz:Zoom>`+
+			`</tptz:Stop>`,
+		profileToken))
+	return err
+}
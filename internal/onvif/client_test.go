@@ -0,0 +1,109 @@
+package onvif
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientGetProfiles(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/soap+xml; charset=utf-8")
+		w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>` + //nolint:errcheck
+			`<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope">` +
+			`<soap:Body>` +
+			`<trt:GetProfilesResponse xmlns:trt="http://www.onvif.org/ver10/media/wsdl">` +
+			`<trt:Profiles token="profile1">` +
+			`<tt:Name xmlns:tt="http://www.onvif.org/ver10/schema">Main</tt:Name>` +
+			`<tt:PTZConfiguration xmlns:tt="http://www.onvif.org/ver10/schema"/>` +
+			`</trt:Profiles>` +
+			`<trt:Profiles token="profile2">` +
+			`<tt:Name xmlns:tt="http://www.onvif.org/ver10/schema">Sub</tt:Name>` +
+			`</trt:Profiles>` +
+			`</trt:GetProfilesResponse>` +
+			`</soap:Body>` +
+			`</soap:Envelope>`))
+	}))
+	defer s.Close()
+
+	c := NewClient(s.URL, "", "")
+
+	profiles, err := c.GetProfiles(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []Profile{
+		{Token: "profile1", Name: "Main", HasPTZ: true},
+		{Token: "profile2", Name: "Sub", HasPTZ: false},
+	}, profiles)
+}
+
+func TestClientGetStreamURI(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/soap+xml; charset=utf-8")
+		w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>` + //nolint:errcheck
+			`<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope">` +
+			`<soap:Body>` +
+			`<trt:GetStreamUriResponse xmlns:trt="http://www.onvif.org/ver10/media/wsdl">` +
+			`<trt:MediaUri xmlns:trt="http://www.onvif.org/ver10/media/wsdl">` +
+			`<tt:Uri xmlns:tt="http://www.onvif.org/ver10/schema">rtsp://192.168.1.1:554/stream1</tt:Uri>` +
+			`</trt:MediaUri>` +
+			`</trt:GetStreamUriResponse>` +
+			`</soap:Body>` +
+			`</soap:Envelope>`))
+	}))
+	defer s.Close()
+
+	c := NewClient(s.URL, "", "")
+
+	uri, err := c.GetStreamURI(context.Background(), "profile1")
+	require.NoError(t, err)
+	require.Equal(t, "rtsp://192.168.1.1:554/stream1", uri)
+}
+
+func TestClientGetStreamURIEmptyURI(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/soap+xml; charset=utf-8")
+		w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>` + //nolint:errcheck
+			`<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope">` +
+			`<soap:Body>` +
+			`<trt:GetStreamUriResponse xmlns:trt="http://www.onvif.org/ver10/media/wsdl">` +
+			`<trt:MediaUri xmlns:trt="http://www.onvif.org/ver10/media/wsdl"></trt:MediaUri>` +
+			`</trt:GetStreamUriResponse>` +
+			`</soap:Body>` +
+			`</soap:Envelope>`))
+	}))
+	defer s.Close()
+
+	c := NewClient(s.URL, "", "")
+
+	_, err := c.GetStreamURI(context.Background(), "profile1")
+	require.Error(t, err)
+}
+
+func TestClientBasicAuth(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "myuser", user)
+		require.Equal(t, "my:pass%word", pass)
+
+		w.Header().Set("Content-Type", "application/soap+xml; charset=utf-8")
+		w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>` + //nolint:errcheck
+			`<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope">` +
+			`<soap:Body>` +
+			`<trt:GetProfilesResponse xmlns:trt="http://www.onvif.org/ver10/media/wsdl"/>` +
+			`</soap:Body>` +
+			`</soap:Envelope>`))
+	}))
+	defer s.Close()
+
+	// the password contains characters (':', '%') that a hashed/validated
+	// conf.Credential would reject; the ONVIF client must accept and send
+	// it verbatim, since it is a plain outbound credential.
+	c := NewClient(s.URL, "myuser", "my:pass%word")
+
+	_, err := c.GetProfiles(context.Background())
+	require.NoError(t, err)
+}
@@ -3,6 +3,7 @@ package recordcleaner
 
 import (
 	"context"
+	"errors"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/bluenviron/mediamtx/internal/conf"
+	"github.com/bluenviron/mediamtx/internal/diskusage"
 	"github.com/bluenviron/mediamtx/internal/logger"
 	"github.com/bluenviron/mediamtx/internal/recordstore"
 )
@@ -104,16 +106,36 @@ func (c *Cleaner) processPath(now time.Time, pathName string) error {
 		return err
 	}
 
-	if pathConf.RecordDeleteAfter == 0 {
-		return nil
+	changed := false
+
+	if pathConf.RecordDeleteAfter != 0 {
+		err = c.deleteExpiredSegments(now, pathName, pathConf)
+		if err != nil {
+			return err
+		}
+
+		changed = true
 	}
 
-	err = c.deleteExpiredSegments(now, pathName, pathConf)
-	if err != nil {
-		return err
+	if pathConf.RecordMaxTotalSize != 0 || pathConf.RecordMaxDiskUsagePercent != 0 {
+		// the 'fifo' policy trickles evictions one segment per cleaner run;
+		// every other policy (including the default) shrinks below the
+		// budget in a single pass.
+		if pathConf.RecordRetentionPolicy == conf.RecordRetentionPolicyFIFO {
+			err = c.deleteOldestSegmentOverQuota(pathName, pathConf)
+		} else {
+			err = c.deleteSegmentsOverQuota(pathName, pathConf)
+		}
+		if err != nil {
+			return err
+		}
+
+		changed = true
 	}
 
-	c.deleteEmptyDirs(pathConf)
+	if changed {
+		c.deleteEmptyDirs(pathConf)
+	}
 
 	return nil
 }
@@ -133,6 +155,114 @@ func (c *Cleaner) deleteExpiredSegments(now time.Time, pathName string, pathConf
 	return nil
 }
 
+// deleteSegmentsOverQuota evicts the oldest segments of a path until the configured
+// total size budget and free-disk-space threshold are satisfied.
+func (c *Cleaner) deleteSegmentsOverQuota(pathName string, pathConf *conf.Path) error {
+	segments, err := recordstore.FindSegments(pathConf, pathName, nil, nil)
+	if err != nil {
+		if errors.Is(err, recordstore.ErrNoSegmentsFound) {
+			return nil
+		}
+		return err
+	}
+
+	// the newest segment may still be open and actively recorded to;
+	// exclude it from eviction to avoid destroying an in-progress recording.
+	segments = segments[:len(segments)-1]
+	if len(segments) == 0 {
+		return nil
+	}
+
+	sizes := make([]int64, len(segments))
+	var totalSize int64
+
+	for i, seg := range segments {
+		info, err := os.Stat(seg.Fpath)
+		if err != nil {
+			continue
+		}
+
+		sizes[i] = info.Size()
+		totalSize += info.Size()
+	}
+
+	recordPath := strings.ReplaceAll(pathConf.RecordPath, "%path", pathConf.Name)
+	commonPath := recordstore.CommonPath(recordPath)
+
+	for len(segments) > 0 {
+		overSize := pathConf.RecordMaxTotalSize != 0 && uint64(totalSize) > uint64(pathConf.RecordMaxTotalSize)
+
+		overDiskUsage := false
+		if pathConf.RecordMaxDiskUsagePercent != 0 {
+			used, err := diskusage.UsedPercent(commonPath)
+			if err != nil {
+				return err
+			}
+
+			overDiskUsage = used > pathConf.RecordMaxDiskUsagePercent
+		}
+
+		if !overSize && !overDiskUsage {
+			break
+		}
+
+		c.Log(logger.Debug, "removing %s (quota exceeded)", segments[0].Fpath)
+		os.Remove(segments[0].Fpath)
+
+		totalSize -= sizes[0]
+		segments = segments[1:]
+		sizes = sizes[1:]
+	}
+
+	return nil
+}
+
+// deleteOldestSegmentOverQuota evicts at most the single oldest segment of a
+// path when the configured total size budget is exceeded. It implements the
+// 'fifo' retention policy, which trickles evictions one segment per cleaner
+// run, as opposed to 'size' (deleteSegmentsOverQuota), which shrinks below
+// the budget in a single pass.
+func (c *Cleaner) deleteOldestSegmentOverQuota(pathName string, pathConf *conf.Path) error {
+	if pathConf.RecordMaxTotalSize == 0 {
+		return nil
+	}
+
+	segments, err := recordstore.FindSegments(pathConf, pathName, nil, nil)
+	if err != nil {
+		if errors.Is(err, recordstore.ErrNoSegmentsFound) {
+			return nil
+		}
+		return err
+	}
+
+	// the newest segment may still be open and actively recorded to;
+	// exclude it from eviction to avoid destroying an in-progress recording.
+	segments = segments[:len(segments)-1]
+	if len(segments) == 0 {
+		return nil
+	}
+
+	var totalSize int64
+
+	for _, seg := range segments {
+		info, err := os.Stat(seg.Fpath)
+		if err != nil {
+			continue
+		}
+
+		totalSize += info.Size()
+	}
+
+	if uint64(totalSize) <= uint64(pathConf.RecordMaxTotalSize) {
+		return nil
+	}
+
+	c.Log(logger.Debug, "removing %s (fifo quota exceeded)", segments[0].Fpath)
+	os.Remove(segments[0].Fpath)
+
+	return nil
+}
+
 func (c *Cleaner) deleteEmptyDirs(pathConf *conf.Path) {
 	recordPath := strings.ReplaceAll(pathConf.RecordPath, "%path", pathConf.Name)
 	commonPath := recordstore.CommonPath(recordPath)
@@ -109,6 +109,130 @@ func TestCleanerMultipleEntriesSamePath(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestCleanerMaxTotalSize(t *testing.T) {
+	timeNow = func() time.Time {
+		return time.Date(2009, 5, 20, 22, 15, 25, 427000, time.Local)
+	}
+
+	dir, err := os.MkdirTemp("", "mediamtx-cleaner")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	err = os.Mkdir(filepath.Join(dir, "mypath"), 0o755)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(dir, "mypath", "2009-05-17_22-15-25-000427.mp4"), []byte{1, 2, 3}, 0o644)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(dir, "mypath", "2009-05-18_22-15-25-000427.mp4"), []byte{1, 2, 3}, 0o644)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(dir, "mypath", "2009-05-19_22-15-25-000427.mp4"), []byte{1, 2, 3}, 0o644)
+	require.NoError(t, err)
+
+	c := &Cleaner{
+		PathConfs: map[string]*conf.Path{
+			"mypath": {
+				Name:               "mypath",
+				RecordPath:         filepath.Join(dir, "%path/%Y-%m-%d_%H-%M-%S-%f"),
+				RecordFormat:       conf.RecordFormatFMP4,
+				RecordMaxTotalSize: 3,
+			},
+		},
+		Parent: test.NilLogger,
+	}
+	c.Initialize()
+	defer c.Close()
+
+	time.Sleep(500 * time.Millisecond)
+
+	_, err = os.Stat(filepath.Join(dir, "mypath", "2009-05-17_22-15-25-000427.mp4"))
+	require.Error(t, err, "oldest segment exceeding the quota should be removed")
+
+	_, err = os.Stat(filepath.Join(dir, "mypath", "2009-05-18_22-15-25-000427.mp4"))
+	require.NoError(t, err, "segment within the quota should remain")
+
+	_, err = os.Stat(filepath.Join(dir, "mypath", "2009-05-19_22-15-25-000427.mp4"))
+	require.NoError(t, err, "newest segment is still open and must never be evicted, even over quota")
+}
+
+func TestCleanerMaxTotalSizeFIFO(t *testing.T) {
+	timeNow = func() time.Time {
+		return time.Date(2009, 5, 20, 22, 15, 25, 427000, time.Local)
+	}
+
+	dir, err := os.MkdirTemp("", "mediamtx-cleaner")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	err = os.Mkdir(filepath.Join(dir, "mypath"), 0o755)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(dir, "mypath", "2009-05-17_22-15-25-000427.mp4"), []byte{1, 2, 3}, 0o644)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(dir, "mypath", "2009-05-18_22-15-25-000427.mp4"), []byte{1, 2, 3}, 0o644)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(dir, "mypath", "2009-05-19_22-15-25-000427.mp4"), []byte{1, 2, 3}, 0o644)
+	require.NoError(t, err)
+
+	c := &Cleaner{
+		PathConfs: map[string]*conf.Path{
+			"mypath": {
+				Name:                  "mypath",
+				RecordPath:            filepath.Join(dir, "%path/%Y-%m-%d_%H-%M-%S-%f"),
+				RecordFormat:          conf.RecordFormatFMP4,
+				RecordMaxTotalSize:    1,
+				RecordRetentionPolicy: conf.RecordRetentionPolicyFIFO,
+			},
+		},
+		Parent: test.NilLogger,
+	}
+	c.doRun()
+
+	_, err = os.Stat(filepath.Join(dir, "mypath", "2009-05-17_22-15-25-000427.mp4"))
+	require.Error(t, err, "oldest segment should be evicted first")
+
+	_, err = os.Stat(filepath.Join(dir, "mypath", "2009-05-18_22-15-25-000427.mp4"))
+	require.NoError(t, err, "only one segment should be evicted per run, even though the quota is still exceeded")
+
+	_, err = os.Stat(filepath.Join(dir, "mypath", "2009-05-19_22-15-25-000427.mp4"))
+	require.NoError(t, err)
+}
+
+func TestCleanerMaxTotalSizeNeverDeletesOpenSegment(t *testing.T) {
+	timeNow = func() time.Time {
+		return time.Date(2009, 5, 20, 22, 15, 25, 427000, time.Local)
+	}
+
+	dir, err := os.MkdirTemp("", "mediamtx-cleaner")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	err = os.Mkdir(filepath.Join(dir, "mypath"), 0o755)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(dir, "mypath", "2009-05-19_22-15-25-000427.mp4"), []byte{1, 2, 3}, 0o644)
+	require.NoError(t, err)
+
+	c := &Cleaner{
+		PathConfs: map[string]*conf.Path{
+			"mypath": {
+				Name:               "mypath",
+				RecordPath:         filepath.Join(dir, "%path/%Y-%m-%d_%H-%M-%S-%f"),
+				RecordFormat:       conf.RecordFormatFMP4,
+				RecordMaxTotalSize: 1,
+			},
+		},
+		Parent: test.NilLogger,
+	}
+	c.doRun()
+
+	_, err = os.Stat(filepath.Join(dir, "mypath", "2009-05-19_22-15-25-000427.mp4"))
+	require.NoError(t, err, "the only segment is still open and must never be evicted, even over quota")
+}
+
 func TestCleanerWithSubdirectories(t *testing.T) {
 	timeNow = func() time.Time {
 		return time.Date(2009, 5, 20, 22, 15, 25, 427000, time.Local)
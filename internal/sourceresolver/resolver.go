@@ -0,0 +1,86 @@
+// Package sourceresolver resolves site-specific source URLs (streaming
+// platforms such as Bilibili, YouTube or Twitch) into a concrete,
+// directly playable HLS/DASH/RTMP URL.
+//
+// Resolution is delegated to the yt-dlp executable, which must be
+// installed separately and be available in PATH.
+package sourceresolver
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/bluenviron/mediamtx/internal/conf"
+)
+
+// siteURL returns the canonical HTTPS URL of a path source that uses one
+// of the supported resolver schemes.
+func siteURL(source string) (string, error) {
+	switch {
+	case strings.HasPrefix(source, "bilibili://"):
+		return "https://live.bilibili.com/" + strings.TrimPrefix(source, "bilibili://"), nil
+
+	case strings.HasPrefix(source, "youtube://"):
+		return "https://www.youtube.com/" + strings.TrimPrefix(source, "youtube://"), nil
+
+	case strings.HasPrefix(source, "twitch://"):
+		return "https://www.twitch.tv/" + strings.TrimPrefix(source, "twitch://"), nil
+
+	default:
+		return "", fmt.Errorf("unsupported source resolver scheme: '%s'", source)
+	}
+}
+
+// Resolve resolves a path with a resolver source scheme (bilibili://,
+// youtube://, twitch://) into a concrete, directly playable URL, by
+// delegating the site-specific scraping to yt-dlp.
+//
+// yt-dlp is a hard runtime dependency of this resolver: it must be
+// installed and available in PATH.
+func Resolve(ctx context.Context, cnf *conf.Path) (string, error) {
+	site, err := siteURL(cnf.Source)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err = exec.LookPath("yt-dlp"); err != nil {
+		return "", fmt.Errorf("the 'yt-dlp' executable is required to resolve '%s' but was not found in PATH: %w",
+			cnf.Source, err)
+	}
+
+	quality := cnf.SourceResolverQuality
+	if quality == "" {
+		quality = "best"
+	}
+
+	args := []string{"-g", "-f", quality, "--no-playlist"}
+
+	if cnf.SourceResolverCookies != "" {
+		args = append(args, "--cookies", cnf.SourceResolverCookies)
+	}
+
+	args = append(args, site)
+
+	cmd := exec.CommandContext(ctx, "yt-dlp", args...)
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	err = cmd.Run()
+	if err != nil {
+		return "", fmt.Errorf("yt-dlp: %w (%s)", err, strings.TrimSpace(stderr.String()))
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) == 0 || lines[0] == "" {
+		return "", fmt.Errorf("yt-dlp returned no playable URL for '%s'", site)
+	}
+
+	return lines[0], nil
+}
@@ -0,0 +1,42 @@
+package sourceresolver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSiteURL(t *testing.T) {
+	for _, ca := range []struct {
+		name   string
+		source string
+		url    string
+	}{
+		{
+			"bilibili",
+			"bilibili://12345",
+			"https://live.bilibili.com/12345",
+		},
+		{
+			"youtube",
+			"youtube://somechannel",
+			"https://www.youtube.com/somechannel",
+		},
+		{
+			"twitch",
+			"twitch://somestreamer",
+			"https://www.twitch.tv/somestreamer",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			u, err := siteURL(ca.source)
+			require.NoError(t, err)
+			require.Equal(t, ca.url, u)
+		})
+	}
+}
+
+func TestSiteURLUnsupportedScheme(t *testing.T) {
+	_, err := siteURL("rtmp://example.com/live")
+	require.Error(t, err)
+}
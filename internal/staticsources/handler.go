@@ -12,9 +12,11 @@ import (
 	"github.com/bluenviron/mediamtx/internal/defs"
 	"github.com/bluenviron/mediamtx/internal/logger"
 	sshls "github.com/bluenviron/mediamtx/internal/staticsources/hls"
+	ssonvif "github.com/bluenviron/mediamtx/internal/staticsources/onvif"
 	ssrpicamera "github.com/bluenviron/mediamtx/internal/staticsources/rpicamera"
 	ssrtmp "github.com/bluenviron/mediamtx/internal/staticsources/rtmp"
 	ssrtsp "github.com/bluenviron/mediamtx/internal/staticsources/rtsp"
+	sssourceresolver "github.com/bluenviron/mediamtx/internal/staticsources/sourceresolver"
 	sssrt "github.com/bluenviron/mediamtx/internal/staticsources/srt"
 	ssudp "github.com/bluenviron/mediamtx/internal/staticsources/udp"
 	sswebrtc "github.com/bluenviron/mediamtx/internal/staticsources/webrtc"
@@ -130,6 +132,23 @@ func (s *Handler) Initialize() {
 			Parent:      s,
 		}
 
+	case strings.HasPrefix(s.Conf.Source, "bilibili://") ||
+		strings.HasPrefix(s.Conf.Source, "youtube://") ||
+		strings.HasPrefix(s.Conf.Source, "twitch://"):
+		s.instance = &sssourceresolver.Source{
+			ReadTimeout:  s.ReadTimeout,
+			WriteTimeout: s.WriteTimeout,
+			Parent:       s,
+		}
+
+	case s.Conf.Source == "onvif":
+		s.instance = &ssonvif.Source{
+			ReadTimeout:    s.ReadTimeout,
+			WriteTimeout:   s.WriteTimeout,
+			WriteQueueSize: s.WriteQueueSize,
+			Parent:         s,
+		}
+
 	case s.Conf.Source == "rpiCamera":
 		s.instance = &ssrpicamera.Source{
 			RTPMaxPayloadSize: s.RTPMaxPayloadSize,
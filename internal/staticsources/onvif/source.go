@@ -0,0 +1,205 @@
+// Package onvif contains the ONVIF static source.
+package onvif
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bluenviron/mediamtx/internal/conf"
+	"github.com/bluenviron/mediamtx/internal/defs"
+	"github.com/bluenviron/mediamtx/internal/logger"
+	"github.com/bluenviron/mediamtx/internal/onvif"
+	ssrtsp "github.com/bluenviron/mediamtx/internal/staticsources/rtsp"
+)
+
+const discoveryRetryPause = 5 * time.Second
+
+type parent interface {
+	logger.Writer
+	SetReady(req defs.PathSourceStaticSetReadyReq) defs.PathSourceStaticSetReadyRes
+	SetNotReady(req defs.PathSourceStaticSetNotReadyReq)
+}
+
+// Source is a source that discovers the RTSP stream URI of an ONVIF camera
+// through the ONVIF media service (GetProfiles, GetStreamUri), then
+// delegates to the RTSP static source. Discovery is retried on failure.
+// When 'onvifPTZEnable' is set and the configured profile supports PTZ,
+// PTZ commands can be sent through PTZMove() and PTZStop().
+type Source struct {
+	ReadTimeout    conf.Duration
+	WriteTimeout   conf.Duration
+	WriteQueueSize int
+	Parent         parent
+
+	mutex        sync.Mutex
+	client       *onvif.Client
+	profileToken string
+}
+
+// Log implements logger.Writer.
+func (s *Source) Log(level logger.Level, format string, args ...interface{}) {
+	s.Parent.Log(level, "[ONVIF source] "+format, args...)
+}
+
+// SetReady is called by the delegate static source.
+func (s *Source) SetReady(req defs.PathSourceStaticSetReadyReq) defs.PathSourceStaticSetReadyRes {
+	return s.Parent.SetReady(req)
+}
+
+// SetNotReady is called by the delegate static source.
+func (s *Source) SetNotReady(req defs.PathSourceStaticSetNotReadyReq) {
+	s.Parent.SetNotReady(req)
+}
+
+// discover queries the device for its media profiles and the RTSP URI of
+// the selected one. When 'onvifPTZEnable' is set, it stores the client so
+// that PTZ commands can later be sent to the same profile.
+func (s *Source) discover(ctx context.Context, cnf *conf.Path) (string, error) {
+	client := onvif.NewClient(cnf.ONVIFDeviceURL, cnf.ONVIFUsername, cnf.ONVIFPassword)
+
+	profiles, err := client.GetProfiles(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	token := cnf.ONVIFProfileToken
+	if token == "" {
+		if len(profiles) == 0 {
+			return "", fmt.Errorf("device exposes no media profiles")
+		}
+		token = profiles[0].Token
+	}
+
+	if cnf.ONVIFPTZEnable {
+		profile, ok := findProfile(profiles, token)
+		if !ok {
+			return "", fmt.Errorf("profile '%s' does not exist", token)
+		}
+
+		if !profile.HasPTZ {
+			return "", fmt.Errorf("profile '%s' does not support PTZ", token)
+		}
+	}
+
+	streamURL, err := client.GetStreamURI(ctx, token)
+	if err != nil {
+		return "", err
+	}
+
+	s.mutex.Lock()
+	if cnf.ONVIFPTZEnable {
+		s.client = client
+		s.profileToken = token
+	} else {
+		s.client = nil
+		s.profileToken = ""
+	}
+	s.mutex.Unlock()
+
+	return streamURL, nil
+}
+
+func findProfile(profiles []onvif.Profile, token string) (onvif.Profile, bool) {
+	for _, p := range profiles {
+		if p.Token == token {
+			return p, true
+		}
+	}
+	return onvif.Profile{}, false
+}
+
+// Run implements StaticSource.
+func (s *Source) Run(params defs.StaticSourceRunParams) error {
+	for {
+		streamURL, err := s.discover(params.Context, params.Conf)
+		if err != nil {
+			s.Log(logger.Warn, "discovery failed: %v, retrying in %v", err, discoveryRetryPause)
+
+			if !s.wait(params.Context) {
+				return nil
+			}
+			continue
+		}
+
+		s.Log(logger.Info, "discovered stream URI '%s'", streamURL)
+
+		delegate := &ssrtsp.Source{
+			ReadTimeout:    s.ReadTimeout,
+			WriteTimeout:   s.WriteTimeout,
+			WriteQueueSize: s.WriteQueueSize,
+			Parent:         s,
+		}
+
+		err = delegate.Run(defs.StaticSourceRunParams{
+			Context:        params.Context,
+			ResolvedSource: streamURL,
+			Conf:           params.Conf,
+			ReloadConf:     params.ReloadConf,
+		})
+		if err == nil {
+			return nil
+		}
+
+		if params.Context.Err() != nil {
+			return nil
+		}
+
+		s.Log(logger.Warn, "%v, re-discovering in %v", err, discoveryRetryPause)
+
+		if !s.wait(params.Context) {
+			return nil
+		}
+	}
+}
+
+// wait blocks until discoveryRetryPause elapses or the context is
+// canceled. It returns false in the latter case.
+func (s *Source) wait(ctx context.Context) bool {
+	select {
+	case <-time.After(discoveryRetryPause):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// PTZMove sends a continuous pan/tilt/zoom command to the camera.
+func (s *Source) PTZMove(ctx context.Context, pan float64, tilt float64, zoom float64) error {
+	client, token, err := s.ptzClient()
+	if err != nil {
+		return err
+	}
+
+	return client.PTZContinuousMove(ctx, token, pan, tilt, zoom)
+}
+
+// PTZStop stops any ongoing PTZ movement.
+func (s *Source) PTZStop(ctx context.Context) error {
+	client, token, err := s.ptzClient()
+	if err != nil {
+		return err
+	}
+
+	return client.PTZStop(ctx, token)
+}
+
+func (s *Source) ptzClient() (*onvif.Client, string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.client == nil {
+		return nil, "", fmt.Errorf("PTZ is not enabled or source has not completed discovery yet")
+	}
+
+	return s.client, s.profileToken, nil
+}
+
+// APISourceDescribe implements StaticSource.
+func (*Source) APISourceDescribe() defs.APIPathSourceOrReader {
+	return defs.APIPathSourceOrReader{
+		Type: "onvifSource",
+		ID:   "",
+	}
+}
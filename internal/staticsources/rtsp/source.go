@@ -70,6 +70,9 @@ type parent interface {
 }
 
 // Source is a RTSP static source.
+//
+// This always pulls through gortsplib.Client; no other RTSP client
+// backend can be selected.
 type Source struct {
 	ReadTimeout    conf.Duration
 	WriteTimeout   conf.Duration
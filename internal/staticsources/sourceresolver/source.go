@@ -0,0 +1,149 @@
+// Package sourceresolver contains the static source that resolves
+// site-specific source URLs before delegating to the underlying protocol.
+package sourceresolver
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/bluenviron/mediamtx/internal/conf"
+	"github.com/bluenviron/mediamtx/internal/defs"
+	"github.com/bluenviron/mediamtx/internal/logger"
+	"github.com/bluenviron/mediamtx/internal/sourceresolver"
+	sshls "github.com/bluenviron/mediamtx/internal/staticsources/hls"
+	ssrtmp "github.com/bluenviron/mediamtx/internal/staticsources/rtmp"
+)
+
+const defaultRefreshInterval = 10 * time.Minute
+
+type parent interface {
+	logger.Writer
+	SetReady(req defs.PathSourceStaticSetReadyReq) defs.PathSourceStaticSetReadyRes
+	SetNotReady(req defs.PathSourceStaticSetNotReadyReq)
+}
+
+// Source is a source that resolves a site-specific URL (e.g. bilibili://,
+// youtube://, twitch://) into a concrete stream URL, then delegates to the
+// HLS or RTMP static source.
+type Source struct {
+	ReadTimeout  conf.Duration
+	WriteTimeout conf.Duration
+	Parent       parent
+}
+
+// Log implements logger.Writer.
+func (s *Source) Log(level logger.Level, format string, args ...interface{}) {
+	s.Parent.Log(level, "[source resolver] "+format, args...)
+}
+
+// SetReady is called by the delegate static source.
+func (s *Source) SetReady(req defs.PathSourceStaticSetReadyReq) defs.PathSourceStaticSetReadyRes {
+	return s.Parent.SetReady(req)
+}
+
+// SetNotReady is called by the delegate static source.
+func (s *Source) SetNotReady(req defs.PathSourceStaticSetNotReadyReq) {
+	s.Parent.SetNotReady(req)
+}
+
+func (s *Source) newDelegate(resolvedURL string) defs.StaticSource {
+	if strings.HasPrefix(resolvedURL, "rtmp://") || strings.HasPrefix(resolvedURL, "rtmps://") {
+		return &ssrtmp.Source{
+			ReadTimeout:  s.ReadTimeout,
+			WriteTimeout: s.WriteTimeout,
+			Parent:       s,
+		}
+	}
+
+	return &sshls.Source{
+		ReadTimeout: s.ReadTimeout,
+		Parent:      s,
+	}
+}
+
+// Run implements StaticSource.
+func (s *Source) Run(params defs.StaticSourceRunParams) error {
+	refresh := time.Duration(params.Conf.SourceResolverRefreshInterval)
+	if refresh == 0 {
+		refresh = defaultRefreshInterval
+	}
+
+	for {
+		resolvedURL, err := sourceresolver.Resolve(params.Context, params.Conf)
+		if err != nil {
+			return err
+		}
+
+		s.Log(logger.Info, "resolved source to %s", resolvedURL)
+
+		runErr, stop := s.runDelegate(params, resolvedURL, refresh)
+		if stop {
+			if params.Context.Err() != nil {
+				return nil
+			}
+			return runErr
+		}
+	}
+}
+
+// runDelegate runs a single resolved-URL session of the delegate source,
+// until it errors, the refresh interval elapses or the outer context is
+// canceled. stop is true when Run() must return runErr to its caller;
+// it is false when the source must re-resolve and start a new session.
+func (s *Source) runDelegate(
+	params defs.StaticSourceRunParams,
+	resolvedURL string,
+	refresh time.Duration,
+) (runErr error, stop bool) {
+	innerCtx, innerCancel := context.WithCancel(params.Context)
+	defer innerCancel()
+
+	innerReloadConf := make(chan *conf.Path)
+
+	delegateErr := make(chan error)
+	go func() {
+		delegateErr <- s.newDelegate(resolvedURL).Run(defs.StaticSourceRunParams{
+			Context:        innerCtx,
+			ResolvedSource: resolvedURL,
+			Conf:           params.Conf,
+			ReloadConf:     innerReloadConf,
+		})
+	}()
+
+	refreshTimer := time.NewTimer(refresh)
+	defer refreshTimer.Stop()
+
+	for {
+		select {
+		case err := <-delegateErr:
+			return err, true
+
+		case <-refreshTimer.C:
+			s.Log(logger.Debug, "refresh interval elapsed, re-resolving source")
+			innerCancel()
+			<-delegateErr
+			return nil, false
+
+		case newConf := <-params.ReloadConf:
+			params.Conf = newConf
+			select {
+			case innerReloadConf <- newConf:
+			case <-innerCtx.Done():
+			}
+
+		case <-params.Context.Done():
+			innerCancel()
+			<-delegateErr
+			return nil, true
+		}
+	}
+}
+
+// APISourceDescribe implements StaticSource.
+func (*Source) APISourceDescribe() defs.APIPathSourceOrReader {
+	return defs.APIPathSourceOrReader{
+		Type: "sourceResolverSource",
+		ID:   "",
+	}
+}
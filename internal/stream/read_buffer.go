@@ -0,0 +1,146 @@
+package stream
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bluenviron/gortsplib/v5/pkg/description"
+	"github.com/bluenviron/gortsplib/v5/pkg/format"
+
+	"github.com/bluenviron/mediamtx/internal/unit"
+)
+
+// Overflow policies for the read buffer.
+const (
+	ReadBufferOverflowPolicyDropOldest       = "drop-oldest"
+	ReadBufferOverflowPolicyDropNewest       = "drop-newest"
+	ReadBufferOverflowPolicyDisconnectReader = "disconnect-reader"
+)
+
+type readBufferEntry struct {
+	ts    time.Time
+	medi  *description.Media
+	forma format.Format
+	u     *unit.Unit
+}
+
+// readBuffer is a bounded, time-indexed queue of the units most recently
+// written to a Stream. It is shared by all readers of the path and is used
+// to compute backpressure metrics and to let a newly added reader rewind
+// within the buffer window. It is not used to detect a lagging reader: see
+// Stream.readerIsLagging for that.
+type readBuffer struct {
+	maxDuration    time.Duration
+	maxPackets     int
+	overflowPolicy string
+
+	mutex        sync.Mutex
+	entries      []readBufferEntry
+	droppedCount uint64
+}
+
+func newReadBuffer(maxDuration time.Duration, maxPackets int, overflowPolicy string) *readBuffer {
+	if overflowPolicy == "" {
+		overflowPolicy = ReadBufferOverflowPolicyDropOldest
+	}
+
+	return &readBuffer{
+		maxDuration:    maxDuration,
+		maxPackets:     maxPackets,
+		overflowPolicy: overflowPolicy,
+	}
+}
+
+// push adds a unit to the buffer, evicting entries that fall outside the
+// time window or the maximum packet count. Under 'drop-newest', a unit that
+// arrives when the buffer is full is discarded instead. The buffer tracks recent units
+// for all readers at once, so its own occupancy says nothing about whether
+// any specific reader is lagging; under the 'disconnect-reader' policy,
+// that is instead detected per-reader, by Stream.readerIsLagging, from
+// each Reader's own backlog. Here, 'disconnect-reader' only affects how
+// the shared history window is trimmed, the same as 'drop-oldest'.
+func (b *readBuffer) push(medi *description.Media, forma format.Format, u *unit.Unit, ts time.Time) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.maxDuration > 0 {
+		threshold := ts.Add(-b.maxDuration)
+		n := 0
+		for n < len(b.entries) && b.entries[n].ts.Before(threshold) {
+			n++
+		}
+		if n > 0 {
+			b.droppedCount += uint64(n)
+			b.entries = b.entries[n:]
+		}
+	}
+
+	if b.maxPackets > 0 && len(b.entries) >= b.maxPackets {
+		switch b.overflowPolicy {
+		case ReadBufferOverflowPolicyDropNewest:
+			b.droppedCount++
+			return
+
+		default: // drop-oldest, disconnect-reader
+			over := len(b.entries) - b.maxPackets + 1
+			b.entries = b.entries[over:]
+			b.droppedCount += uint64(over)
+		}
+	}
+
+	b.entries = append(b.entries, readBufferEntry{ts: ts, medi: medi, forma: forma, u: u})
+}
+
+// snapshot returns a copy of the units currently stored in the buffer, in
+// the order they were written. It is used to let a newly added reader
+// rewind within the buffer window.
+func (b *readBuffer) snapshot() []readBufferEntry {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	entries := make([]readBufferEntry, len(b.entries))
+	copy(entries, b.entries)
+	return entries
+}
+
+// depth returns the current number of units stored in the buffer.
+func (b *readBuffer) depth() int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return len(b.entries)
+}
+
+// droppedPackets returns the total number of units evicted from the buffer
+// due to the time window or the maximum packet count being exceeded, plus
+// the units discarded on arrival under 'drop-newest'.
+func (b *readBuffer) droppedPackets() uint64 {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.droppedCount
+}
+
+// oldestPacketAge returns the age of the oldest unit currently stored in
+// the buffer, or 0 if the buffer is empty.
+func (b *readBuffer) oldestPacketAge(now time.Time) time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if len(b.entries) == 0 {
+		return 0
+	}
+
+	return now.Sub(b.entries[0].ts)
+}
+
+// timestamps returns the timestamps of the oldest (head) and newest (tail)
+// units currently stored in the buffer. ok is false if the buffer is empty.
+func (b *readBuffer) timestamps() (head time.Time, tail time.Time, ok bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if len(b.entries) == 0 {
+		return time.Time{}, time.Time{}, false
+	}
+
+	return b.entries[0].ts, b.entries[len(b.entries)-1].ts, true
+}
@@ -0,0 +1,75 @@
+package stream
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bluenviron/mediamtx/internal/unit"
+)
+
+func TestReadBufferMaxPackets(t *testing.T) {
+	b := newReadBuffer(0, 2, ReadBufferOverflowPolicyDropOldest)
+
+	now := time.Now()
+
+	b.push(nil, nil, &unit.Unit{PTS: 1}, now)
+	b.push(nil, nil, &unit.Unit{PTS: 2}, now.Add(1*time.Second))
+	b.push(nil, nil, &unit.Unit{PTS: 3}, now.Add(2*time.Second))
+
+	require.Equal(t, 2, b.depth())
+	require.Equal(t, uint64(1), b.droppedPackets())
+
+	head, tail, ok := b.timestamps()
+	require.True(t, ok)
+	require.Equal(t, now.Add(1*time.Second), head)
+	require.Equal(t, now.Add(2*time.Second), tail)
+}
+
+func TestReadBufferDuration(t *testing.T) {
+	b := newReadBuffer(1*time.Second, 0, ReadBufferOverflowPolicyDropOldest)
+
+	now := time.Now()
+
+	b.push(nil, nil, &unit.Unit{PTS: 1}, now)
+	b.push(nil, nil, &unit.Unit{PTS: 2}, now.Add(2*time.Second))
+
+	require.Equal(t, 1, b.depth())
+	require.Equal(t, uint64(1), b.droppedPackets())
+}
+
+func TestReadBufferDropNewest(t *testing.T) {
+	b := newReadBuffer(0, 1, ReadBufferOverflowPolicyDropNewest)
+
+	now := time.Now()
+
+	b.push(nil, nil, &unit.Unit{PTS: 1}, now)
+	b.push(nil, nil, &unit.Unit{PTS: 2}, now.Add(1*time.Second))
+
+	require.Equal(t, 1, b.depth())
+	require.Equal(t, uint64(1), b.droppedPackets())
+
+	_, tail, ok := b.timestamps()
+	require.True(t, ok)
+	require.Equal(t, now, tail)
+}
+
+// under the 'disconnect-reader' policy, the shared buffer itself has no
+// notion of readers; it just trims its history window like 'drop-oldest'.
+// Actual per-reader disconnection is tested in stream_test.go.
+func TestReadBufferDisconnectReaderTrimsLikeDropOldest(t *testing.T) {
+	b := newReadBuffer(0, 1, ReadBufferOverflowPolicyDisconnectReader)
+
+	now := time.Now()
+
+	b.push(nil, nil, &unit.Unit{PTS: 1}, now)
+	b.push(nil, nil, &unit.Unit{PTS: 2}, now.Add(1*time.Second))
+
+	require.Equal(t, 1, b.depth())
+	require.Equal(t, uint64(1), b.droppedPackets())
+
+	_, tail, ok := b.timestamps()
+	require.True(t, ok)
+	require.Equal(t, now.Add(1*time.Second), tail)
+}
@@ -2,6 +2,7 @@ package stream
 
 import (
 	"fmt"
+	"sync/atomic"
 
 	"github.com/bluenviron/gortsplib/v5/pkg/description"
 	"github.com/bluenviron/gortsplib/v5/pkg/format"
@@ -23,6 +24,7 @@ type Reader struct {
 	queueSize       int
 	buffer          *ringbuffer.RingBuffer
 	discardedFrames *counterdumper.Dumper
+	backlog         int64
 
 	// out
 	err chan error
@@ -111,6 +113,8 @@ func (r *Reader) runInner() error {
 			return fmt.Errorf("terminated")
 		}
 
+		atomic.AddInt64(&r.backlog, -1)
+
 		err := cb.(func() error)()
 		if err != nil {
 			return err
@@ -122,5 +126,16 @@ func (r *Reader) push(cb func() error) {
 	ok := r.buffer.Push(cb)
 	if !ok {
 		r.discardedFrames.Increase()
+		return
 	}
+
+	atomic.AddInt64(&r.backlog, 1)
+}
+
+// backlogLen returns the number of units that have been queued to this
+// reader but not yet processed by its own goroutine. It is used to detect
+// a reader that is lagging behind the rest, as opposed to the shared read
+// buffer, whose occupancy reflects all readers at once.
+func (r *Reader) backlogLen() int64 {
+	return atomic.LoadInt64(&r.backlog)
 }
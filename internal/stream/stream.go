@@ -19,29 +19,33 @@ import (
 // Stream is a media stream.
 // It stores tracks, readers and allows to write data to readers, converting it when needed.
 type Stream struct {
-	WriteQueueSize     int
-	RTPMaxPayloadSize  int
-	Desc               *description.Session
-	GenerateRTPPackets bool
-	FillNTP            bool
-	Parent             logger.Writer
-
-	bytesReceived     *uint64
-	bytesSent         *uint64
-	lastRTPTimestamp  *int64 // Unix timestamp in nanoseconds
-	keyFramesCount    map[string]*uint64 // per codec key frame count
-	lastKeyFrameTS    map[string]*int64  // per codec last key frame timestamp (Unix nanoseconds)
-	prevKeyFrameTS    map[string]*int64  // per codec previous key frame timestamp (Unix nanoseconds)
-	lastGOPSize       map[string]*int64   // per codec last GOP size in nanoseconds
-	frameTimestamps   map[string][]int64  // per codec frame timestamps for FPS calculation (sliding window)
-	fpsMutex          sync.RWMutex        // mutex for frame timestamps
-	keyFramesMutex    sync.RWMutex
-	medias            map[*description.Media]*streamMedia
-	mutex             sync.RWMutex
-	rtspStream        *gortsplib.ServerStream
-	rtspsStream       *gortsplib.ServerStream
-	readers           map[*Reader]struct{}
-	processingErrors  *counterdumper.CounterDumper
+	WriteQueueSize           int
+	RTPMaxPayloadSize        int
+	Desc                     *description.Session
+	GenerateRTPPackets       bool
+	FillNTP                  bool
+	ReadBufferDuration       time.Duration
+	ReadBufferMaxPackets     int
+	ReadBufferOverflowPolicy string
+	Parent                   logger.Writer
+
+	readBuffer       *readBuffer
+	bytesReceived    *uint64
+	bytesSent        *uint64
+	lastRTPTimestamp *int64             // Unix timestamp in nanoseconds
+	keyFramesCount   map[string]*uint64 // per codec key frame count
+	lastKeyFrameTS   map[string]*int64  // per codec last key frame timestamp (Unix nanoseconds)
+	prevKeyFrameTS   map[string]*int64  // per codec previous key frame timestamp (Unix nanoseconds)
+	lastGOPSize      map[string]*int64  // per codec last GOP size in nanoseconds
+	frameTimestamps  map[string][]int64 // per codec frame timestamps for FPS calculation (sliding window)
+	fpsMutex         sync.RWMutex       // mutex for frame timestamps
+	keyFramesMutex   sync.RWMutex
+	medias           map[*description.Media]*streamMedia
+	mutex            sync.RWMutex
+	rtspStream       *gortsplib.ServerStream
+	rtspsStream      *gortsplib.ServerStream
+	readers          map[*Reader]struct{}
+	processingErrors *counterdumper.CounterDumper
 }
 
 // Initialize initializes a Stream.
@@ -57,6 +61,10 @@ func (s *Stream) Initialize() error {
 	s.medias = make(map[*description.Media]*streamMedia)
 	s.readers = make(map[*Reader]struct{})
 
+	if s.ReadBufferDuration > 0 || s.ReadBufferMaxPackets > 0 {
+		s.readBuffer = newReadBuffer(s.ReadBufferDuration, s.ReadBufferMaxPackets, s.ReadBufferOverflowPolicy)
+	}
+
 	s.processingErrors = &counterdumper.CounterDumper{
 		OnReport: func(val uint64) {
 			s.Parent.Log(logger.Warn, "%d processing %s",
@@ -135,7 +143,7 @@ func (s *Stream) LastRTPTimestamp() int64 {
 func (s *Stream) KeyFramesCount(codec string) uint64 {
 	s.keyFramesMutex.RLock()
 	defer s.keyFramesMutex.RUnlock()
-	
+
 	if codec == "" {
 		total := uint64(0)
 		for _, count := range s.keyFramesCount {
@@ -143,7 +151,7 @@ func (s *Stream) KeyFramesCount(codec string) uint64 {
 		}
 		return total
 	}
-	
+
 	if count, ok := s.keyFramesCount[codec]; ok {
 		return atomic.LoadUint64(count)
 	}
@@ -154,7 +162,7 @@ func (s *Stream) KeyFramesCount(codec string) uint64 {
 func (s *Stream) KeyFramesCountPerCodec() map[string]uint64 {
 	s.keyFramesMutex.RLock()
 	defer s.keyFramesMutex.RUnlock()
-	
+
 	result := make(map[string]uint64)
 	for codec, count := range s.keyFramesCount {
 		result[codec] = atomic.LoadUint64(count)
@@ -167,7 +175,7 @@ func (s *Stream) KeyFramesCountPerCodec() map[string]uint64 {
 func (s *Stream) LastKeyFrameTimestamp(codec string) int64 {
 	s.keyFramesMutex.RLock()
 	defer s.keyFramesMutex.RUnlock()
-	
+
 	if codec == "" {
 		var maxTS int64
 		for _, ts := range s.lastKeyFrameTS {
@@ -177,7 +185,7 @@ func (s *Stream) LastKeyFrameTimestamp(codec string) int64 {
 		}
 		return maxTS
 	}
-	
+
 	if ts, ok := s.lastKeyFrameTS[codec]; ok {
 		return atomic.LoadInt64(ts)
 	}
@@ -188,7 +196,7 @@ func (s *Stream) LastKeyFrameTimestamp(codec string) int64 {
 func (s *Stream) LastKeyFrameTimestampPerCodec() map[string]int64 {
 	s.keyFramesMutex.RLock()
 	defer s.keyFramesMutex.RUnlock()
-	
+
 	result := make(map[string]int64)
 	for codec, ts := range s.lastKeyFrameTS {
 		result[codec] = atomic.LoadInt64(ts)
@@ -200,7 +208,7 @@ func (s *Stream) LastKeyFrameTimestampPerCodec() map[string]int64 {
 func (s *Stream) LastGOPSizePerCodec() map[string]int64 {
 	s.keyFramesMutex.RLock()
 	defer s.keyFramesMutex.RUnlock()
-	
+
 	result := make(map[string]int64)
 	for codec, gopSize := range s.lastGOPSize {
 		result[codec] = atomic.LoadInt64(gopSize)
@@ -213,11 +221,11 @@ func (s *Stream) LastGOPSizePerCodec() map[string]int64 {
 func (s *Stream) FPSPerCodec() map[string]float64 {
 	s.fpsMutex.RLock()
 	defer s.fpsMutex.RUnlock()
-	
+
 	result := make(map[string]float64)
 	now := time.Now().UnixNano()
 	oneSecondAgo := now - int64(time.Second)
-	
+
 	for codec, timestamps := range s.frameTimestamps {
 		// Count frames in the last second
 		count := 0
@@ -286,6 +294,33 @@ func (s *Stream) AddReader(r *Reader) {
 
 	r.queueSize = s.WriteQueueSize
 	r.start()
+
+	if s.readBuffer != nil {
+		s.rewindReader(r)
+	}
+}
+
+// rewindReader delivers the units currently stored in the read buffer to a
+// newly added reader, so that it doesn't have to wait for new data to
+// arrive before it can start playing.
+func (s *Stream) rewindReader(r *Reader) {
+	for _, entry := range s.readBuffer.snapshot() {
+		formats, ok := r.onDatas[entry.medi]
+		if !ok {
+			continue
+		}
+
+		onData, ok := formats[entry.forma]
+		if !ok {
+			continue
+		}
+
+		u := entry.u
+		cOnData := onData
+		r.push(func() error {
+			return cOnData(u)
+		})
+	}
 }
 
 // RemoveReader removes a reader.
@@ -316,9 +351,77 @@ func (s *Stream) WriteUnit(medi *description.Media, forma format.Format, u *unit
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
+	if s.readBuffer != nil {
+		s.readBuffer.push(medi, forma, u, time.Now())
+	}
+
 	sf.writeUnit(s, medi, u)
 }
 
+// readerIsLagging reports whether r is a candidate for disconnection under
+// the 'disconnect-reader' overflow policy: its own unconsumed backlog, not
+// the shared read buffer's occupancy, has reached ReadBufferMaxPackets.
+func (s *Stream) readerIsLagging(r *Reader) bool {
+	return s.ReadBufferOverflowPolicy == ReadBufferOverflowPolicyDisconnectReader &&
+		s.ReadBufferMaxPackets > 0 &&
+		r.backlogLen() >= int64(s.ReadBufferMaxPackets)
+}
+
+// disconnectReader forcibly removes a single reader of the stream. It is
+// called, async, when readerIsLagging reports that this specific reader
+// fell behind; WriteUnit already holds s.mutex for reading, so this must
+// not be called synchronously from within it.
+func (s *Stream) disconnectReader(r *Reader) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.readers[r]; !ok {
+		return // already removed
+	}
+
+	r.stop()
+
+	for medi, formats := range r.onDatas {
+		sm := s.medias[medi]
+
+		for forma := range formats {
+			sf := sm.formats[forma]
+			delete(sf.onDatas, r)
+		}
+	}
+
+	delete(s.readers, r)
+}
+
+// ReadBufferDepth returns the number of units currently stored in the read
+// buffer, or 0 if no read buffer is configured.
+func (s *Stream) ReadBufferDepth() int {
+	if s.readBuffer == nil {
+		return 0
+	}
+	return s.readBuffer.depth()
+}
+
+// ReadBufferDroppedPackets returns the number of units evicted from the
+// read buffer or discarded by it under 'drop-newest', or 0 if no read
+// buffer is configured.
+func (s *Stream) ReadBufferDroppedPackets() uint64 {
+	if s.readBuffer == nil {
+		return 0
+	}
+	return s.readBuffer.droppedPackets()
+}
+
+// ReadBufferOldestPacketAge returns the age of the oldest unit currently
+// stored in the read buffer, or 0 if no read buffer is configured or the
+// buffer is empty.
+func (s *Stream) ReadBufferOldestPacketAge() time.Duration {
+	if s.readBuffer == nil {
+		return 0
+	}
+	return s.readBuffer.oldestPacketAge(time.Now())
+}
+
 // WriteRTPPacket writes a RTP packet.
 func (s *Stream) WriteRTPPacket(
 	medi *description.Media,
@@ -232,5 +232,9 @@ func (sf *streamFormat) writeUnitInner(s *Stream, medi *description.Media, u *un
 			}
 			return cOnData(u)
 		})
+
+		if s.readerIsLagging(csr) {
+			go s.disconnectReader(csr)
+		}
 	}
 }
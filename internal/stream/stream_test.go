@@ -2,6 +2,7 @@ package stream
 
 import (
 	"testing"
+	"time"
 
 	"github.com/bluenviron/gortsplib/v5/pkg/description"
 	"github.com/bluenviron/gortsplib/v5/pkg/format"
@@ -104,3 +105,52 @@ func TestStreamSkipBytesSent(t *testing.T) {
 	require.Equal(t, uint64(14), strm.BytesReceived())
 	require.Equal(t, uint64(0), strm.BytesSent())
 }
+
+func TestStreamDisconnectReaderDetectsOwnBacklogNotSharedBuffer(t *testing.T) {
+	desc := &description.Session{Medias: []*description.Media{
+		{
+			Type:    description.MediaTypeVideo,
+			Formats: []format.Format{&format.H264{}},
+		},
+	}}
+
+	strm := &Stream{
+		WriteQueueSize:           8,
+		RTPMaxPayloadSize:        1450,
+		Desc:                     desc,
+		GenerateRTPPackets:       true,
+		ReadBufferMaxPackets:     1,
+		ReadBufferOverflowPolicy: ReadBufferOverflowPolicyDisconnectReader,
+	}
+	err := strm.Initialize()
+	require.NoError(t, err)
+	defer strm.Close()
+
+	// a reader that is slow to consume, so that it falls behind its own
+	// queue; the shared read buffer holds at most one unit here and just
+	// trims its history on every write, which by itself never disconnects
+	// a reader.
+	r := &Reader{}
+	r.OnData(desc.Medias[0], desc.Medias[0].Formats[0], func(_ *unit.Unit) error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	})
+
+	strm.AddReader(r)
+
+	for i := 0; i < 5; i++ {
+		strm.WriteUnit(desc.Medias[0], desc.Medias[0].Formats[0], &unit.Unit{
+			PTS: int64(i),
+			Payload: unit.PayloadH264{
+				{5, 2}, // IDR
+			},
+		})
+	}
+
+	require.Eventually(t, func() bool {
+		strm.mutex.RLock()
+		defer strm.mutex.RUnlock()
+		_, ok := strm.readers[r]
+		return !ok
+	}, 2*time.Second, 10*time.Millisecond, "reader lagging behind its own queue should be disconnected")
+}